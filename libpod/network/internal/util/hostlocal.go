@@ -0,0 +1,147 @@
+package util
+
+import (
+	"net"
+	"sync"
+
+	"github.com/containers/podman/v3/libpod/network/types"
+	pkgutil "github.com/containers/podman/v3/libpod/network/util"
+	"github.com/pkg/errors"
+)
+
+func init() {
+	RegisterIPAMDriver(&hostLocalIPAM{leases: map[string]net.IP{}})
+}
+
+// hostLocalIPAM backs the "host-local" name with a simple sequential
+// allocator, so that setting PerNetworkOptions.IPAMDriver to "host-local"
+// behaves like leaving it empty instead of failing with "unknown ipam
+// driver". It walks s.LeaseRanges when configured (falling back to the
+// whole subnet otherwise), skipping s.ExcludedRanges, the gateway, the
+// IPv4 broadcast address, and anything it has already leased.
+type hostLocalIPAM struct {
+	mu sync.Mutex
+	// leases maps "network/interface" to the ip handed out for it.
+	leases map[string]net.IP
+}
+
+func (d *hostLocalIPAM) Name() string {
+	return "host-local"
+}
+
+func (d *hostLocalIPAM) Contains(network *types.Network, ip net.IP) bool {
+	for _, s := range network.Subnets {
+		if s.Subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func (d *hostLocalIPAM) RequestIP(network *types.Network, netOpts *types.PerNetworkOptions) (net.IP, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	key := network.Name + "/" + netOpts.InterfaceName
+	if len(netOpts.StaticIPs) > 0 {
+		ip := netOpts.StaticIPs[0]
+		d.leases[key] = ip
+		return ip, nil
+	}
+
+	for i := range network.Subnets {
+		s := &network.Subnets[i]
+		_, subnet, err := net.ParseCIDR(s.Subnet.String())
+		if err != nil {
+			continue
+		}
+		ranges, err := candidateRanges(s, subnet)
+		if err != nil {
+			continue
+		}
+		for _, r := range ranges {
+			for ip := r.start; subnet.Contains(ip) && compareIPs(ip, r.end) <= 0; ip = nextIP(ip) {
+				if d.isReserved(s, subnet, ip) {
+					continue
+				}
+				d.leases[key] = ip
+				return ip, nil
+			}
+		}
+	}
+	return nil, errors.Errorf("no free ip available for network %s", network.Name)
+}
+
+func (d *hostLocalIPAM) ReleaseIP(network *types.Network, netOpts *types.PerNetworkOptions) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	delete(d.leases, network.Name+"/"+netOpts.InterfaceName)
+	return nil
+}
+
+// isReserved reports whether ip must never be handed out: it is the
+// gateway, the IPv4 broadcast address, inside an excluded range, or
+// already leased to any interface, including the one now requesting.
+func (d *hostLocalIPAM) isReserved(s *types.Subnet, subnet *net.IPNet, ip net.IP) bool {
+	if s.Gateway != nil && ip.Equal(s.Gateway) {
+		return true
+	}
+	if pkgutil.IsIPv4(ip) {
+		if broadcast, err := pkgutil.LastIPInSubnet(subnet); err == nil && ip.Equal(broadcast) {
+			return true
+		}
+	}
+	if IPInExcludedRanges(s, ip) {
+		return true
+	}
+	for _, leased := range d.leases {
+		if leased.Equal(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ipRange is an inclusive [start, end] address range to draw candidate
+// IPs from.
+type ipRange struct {
+	start, end net.IP
+}
+
+// candidateRanges returns the ranges RequestIP should scan for a free IP:
+// s.LeaseRanges when configured, otherwise the whole subnet.
+func candidateRanges(s *types.Subnet, subnet *net.IPNet) ([]ipRange, error) {
+	ranges := make([]ipRange, 0, len(s.LeaseRanges))
+	for _, lr := range s.LeaseRanges {
+		if lr.StartIP == nil || lr.EndIP == nil {
+			continue
+		}
+		ranges = append(ranges, ipRange{start: lr.StartIP, end: lr.EndIP})
+	}
+	if len(ranges) > 0 {
+		return ranges, nil
+	}
+
+	first, err := pkgutil.FirstIPInSubnet(subnet)
+	if err != nil {
+		return nil, err
+	}
+	last, err := pkgutil.LastIPInSubnet(subnet)
+	if err != nil {
+		return nil, err
+	}
+	return []ipRange{{start: first, end: last}}, nil
+}
+
+// nextIP returns the ip immediately following ip.
+func nextIP(ip net.IP) net.IP {
+	next := make(net.IP, len(ip))
+	copy(next, ip)
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		}
+	}
+	return next
+}
@@ -0,0 +1,51 @@
+package util
+
+import (
+	"net"
+
+	"github.com/containers/podman/v3/libpod/network/types"
+)
+
+// IPAMDriver is implemented by IP allocation backends that replace the
+// built-in subnet allocator for a network attachment, e.g. to hand address
+// management off to an external/corporate IPAM system. A driver is selected
+// per attachment via PerNetworkOptions.IPAMDriver; when that field is empty
+// the built-in allocator is used instead. The "host-local" name is always
+// registered (see hostlocal.go) and mirrors that built-in behavior, so
+// requesting it explicitly does not fail validation.
+//
+// RequestIP/ReleaseIP are invoked by the network backend's (CNI/netavark)
+// setup and teardown code, not by this package.
+type IPAMDriver interface {
+	// Name returns the name this driver is registered under.
+	Name() string
+	// Contains reports whether ip is a valid address for the given
+	// network as far as this driver is concerned. External IPAM drivers
+	// may own pools outside of any configured types.Subnet, so this
+	// replaces the built-in "is it in one of the Subnets" check.
+	Contains(network *types.Network, ip net.IP) bool
+	// RequestIP allocates (or confirms) an IP for the given container on
+	// the given network. Invoked by the network backend when a
+	// container's namespace is set up.
+	RequestIP(network *types.Network, netOpts *types.PerNetworkOptions) (net.IP, error)
+	// ReleaseIP returns a previously allocated IP back to the driver.
+	// Invoked by the network backend when a container's namespace is
+	// torn down.
+	ReleaseIP(network *types.Network, netOpts *types.PerNetworkOptions) error
+}
+
+var ipamDrivers = map[string]IPAMDriver{}
+
+// RegisterIPAMDriver makes an external IPAM driver available under the name
+// returned by its Name() method. Drivers register at initialization time;
+// this package registers only its own "host-local" driver (from the init
+// in hostlocal.go) and never imports a specific backend.
+func RegisterIPAMDriver(driver IPAMDriver) {
+	ipamDrivers[driver.Name()] = driver
+}
+
+// getIPAMDriver looks up a registered IPAM driver by name.
+func getIPAMDriver(name string) (IPAMDriver, bool) {
+	driver, ok := ipamDrivers[name]
+	return driver, ok
+}
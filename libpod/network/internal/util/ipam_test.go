@@ -0,0 +1,100 @@
+package util
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/podman/v3/libpod/network/types"
+)
+
+func TestValidatePerNetworkOptsHostLocalDriver(t *testing.T) {
+	network := &types.Network{
+		Name: "testnet",
+		Subnets: []types.Subnet{
+			{Subnet: mustParseCIDR(t, "192.168.1.0/24")},
+		},
+	}
+
+	// in subnet: must pass
+	err := validatePerNetworkOpts(network, types.PerNetworkOptions{
+		InterfaceName: "eth0",
+		IPAMDriver:    "host-local",
+		StaticIPs:     []net.IP{net.ParseIP("192.168.1.25")},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error for ip inside subnet: %v", err)
+	}
+
+	// outside subnet: must fail, same as the built-in allocator would
+	err = validatePerNetworkOpts(network, types.PerNetworkOptions{
+		InterfaceName: "eth0",
+		IPAMDriver:    "host-local",
+		StaticIPs:     []net.IP{net.ParseIP("10.0.0.5")},
+	})
+	if err == nil {
+		t.Fatal("expected an error for ip outside subnet, got none")
+	}
+}
+
+func TestHostLocalIPAMRequestIPSkipsGateway(t *testing.T) {
+	driver, ok := getIPAMDriver("host-local")
+	if !ok {
+		t.Fatal("host-local driver not registered")
+	}
+
+	network := &types.Network{
+		Name: "gwtest",
+		Subnets: []types.Subnet{
+			{
+				Subnet:  mustParseCIDR(t, "192.168.42.0/24"),
+				Gateway: net.ParseIP("192.168.42.1"),
+			},
+		},
+	}
+	ip, err := driver.RequestIP(network, &types.PerNetworkOptions{InterfaceName: "eth0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ip.Equal(network.Subnets[0].Gateway) {
+		t.Fatalf("RequestIP handed out the gateway address %s", ip)
+	}
+}
+
+func TestHostLocalIPAMRequestIPHonorsLeaseRanges(t *testing.T) {
+	driver, ok := getIPAMDriver("host-local")
+	if !ok {
+		t.Fatal("host-local driver not registered")
+	}
+
+	network := &types.Network{
+		Name: "leasetest",
+		Subnets: []types.Subnet{
+			{
+				Subnet:  mustParseCIDR(t, "192.168.43.0/24"),
+				Gateway: net.ParseIP("192.168.43.1"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.43.200"), EndIP: net.ParseIP("192.168.43.210")},
+				},
+			},
+		},
+	}
+	ip, err := driver.RequestIP(network, &types.PerNetworkOptions{InterfaceName: "eth0"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	lr := network.Subnets[0].LeaseRanges[0]
+	if !rangeContains(lr.StartIP, lr.EndIP, ip) {
+		t.Fatalf("RequestIP returned %s, want an address inside the configured lease range %s-%s", ip, lr.StartIP, lr.EndIP)
+	}
+}
+
+func TestValidatePerNetworkOptsUnknownDriver(t *testing.T) {
+	network := &types.Network{Name: "testnet"}
+	err := validatePerNetworkOpts(network, types.PerNetworkOptions{
+		InterfaceName: "eth0",
+		IPAMDriver:    "does-not-exist",
+	})
+	if err == nil {
+		t.Fatal("expected an error for an unregistered ipam driver, got none")
+	}
+}
@@ -0,0 +1,91 @@
+package util
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/podman/v3/libpod/network/types"
+)
+
+func TestValidateSubnetLeaseRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		subnet  types.Subnet
+		wantErr bool
+	}{
+		{
+			name: "two discontiguous lease ranges",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.10"), EndIP: net.ParseIP("192.168.1.50")},
+					{StartIP: net.ParseIP("192.168.1.200"), EndIP: net.ParseIP("192.168.1.250")},
+				},
+			},
+		},
+		{
+			name: "lease range outside subnet",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.2.10"), EndIP: net.ParseIP("192.168.2.50")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "lease range start after end",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.50"), EndIP: net.ParseIP("192.168.1.10")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "lease ranges overlap each other",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.10"), EndIP: net.ParseIP("192.168.1.50")},
+					{StartIP: net.ParseIP("192.168.1.40"), EndIP: net.ParseIP("192.168.1.60")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway inside lease range",
+			subnet: types.Subnet{
+				Subnet:  mustParseCIDR(t, "192.168.1.0/24"),
+				Gateway: net.ParseIP("192.168.1.20"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.10"), EndIP: net.ParseIP("192.168.1.50")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "gateway outside all lease ranges",
+			subnet: types.Subnet{
+				Subnet:  mustParseCIDR(t, "192.168.1.0/24"),
+				Gateway: net.ParseIP("192.168.1.1"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.10"), EndIP: net.ParseIP("192.168.1.50")},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSubnet(&tt.subnet, false, nil)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+package util
+
+import (
+	"crypto/sha256"
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// ValidateStaticMAC checks that mac is a well formed unicast hardware
+// address, rejecting multicast and broadcast addresses.
+func ValidateStaticMAC(mac net.HardwareAddr) error {
+	if len(mac) == 0 {
+		return errors.New("mac address is empty")
+	}
+	broadcast := true
+	for _, b := range mac {
+		if b != 0xff {
+			broadcast = false
+			break
+		}
+	}
+	if broadcast {
+		return errors.Errorf("mac address %s is a broadcast address", mac)
+	}
+	if mac[0]&1 == 1 {
+		return errors.Errorf("mac address %s is a multicast address", mac)
+	}
+	return nil
+}
+
+// StableMACForContainer deterministically derives a locally administered
+// unicast MAC address from containerID. The CNI and netavark code paths
+// can call this to give a container interface a stable address when the
+// user did not request a specific one.
+func StableMACForContainer(containerID string) (net.HardwareAddr, error) {
+	if containerID == "" {
+		return nil, errors.New("containerID is empty")
+	}
+	sum := sha256.Sum256([]byte(containerID))
+	mac := make(net.HardwareAddr, 6)
+	copy(mac, sum[:6])
+	// Mark the address as locally administered and unicast so it can
+	// never collide with a vendor-assigned address.
+	mac[0] = (mac[0] | 0x02) & 0xfe
+	return mac, nil
+}
@@ -0,0 +1,71 @@
+package util
+
+import (
+	"net"
+	"testing"
+)
+
+func TestValidateStaticMAC(t *testing.T) {
+	tests := []struct {
+		name    string
+		mac     string
+		wantErr bool
+	}{
+		{"valid unicast mac", "02:42:ac:11:00:02", false},
+		{"multicast mac", "01:00:5e:00:00:01", true},
+		{"broadcast mac", "ff:ff:ff:ff:ff:ff", true},
+		{"empty mac", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mac net.HardwareAddr
+			if tt.mac != "" {
+				var err error
+				mac, err = net.ParseMAC(tt.mac)
+				if err != nil {
+					t.Fatalf("invalid test mac %s: %v", tt.mac, err)
+				}
+			}
+			err := ValidateStaticMAC(mac)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestStableMACForContainer(t *testing.T) {
+	mac1, err := StableMACForContainer("abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	mac2, err := StableMACForContainer("abc123")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mac1.String() != mac2.String() {
+		t.Fatalf("StableMACForContainer is not deterministic: %s != %s", mac1, mac2)
+	}
+
+	mac3, err := StableMACForContainer("different-container")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mac1.String() == mac3.String() {
+		t.Fatalf("different container ids produced the same mac: %s", mac1)
+	}
+
+	if mac1[0]&0x02 == 0 {
+		t.Errorf("mac %s is not marked locally administered", mac1)
+	}
+	if mac1[0]&0x01 != 0 {
+		t.Errorf("mac %s is marked multicast", mac1)
+	}
+
+	if _, err := StableMACForContainer(""); err == nil {
+		t.Fatal("expected an error for an empty container id, got none")
+	}
+}
@@ -0,0 +1,38 @@
+package util
+
+import (
+	"net"
+
+	"github.com/containers/podman/v3/libpod/network/types"
+)
+
+// NetUtil wraps basic network config lookups so the validation helpers in
+// this package do not need to know how a given backend (CNI, netavark, ...)
+// persists its network configuration.
+type NetUtil interface {
+	// Network returns the Network with the given name.
+	Network(name string) (*types.Network, error)
+}
+
+// NetUtilMACChecker is an optional interface a NetUtil backend can
+// implement to expose MAC leases across containers on a network. Only a
+// backend knows which containers are currently attached to a network, so
+// ValidateSetupOptions cannot reject a mac already leased to another
+// container unless the backend implements this; without it only duplicates
+// within the same ValidateSetupOptions call are caught.
+type NetUtilMACChecker interface {
+	// MACLeased reports whether mac is already leased to a container on
+	// the named network, and if so which one.
+	MACLeased(network string, mac net.HardwareAddr) (containerID string, leased bool)
+}
+
+// NetworkIntersectsWithNetworks returns true if the given network intersects
+// with any of the networks in the given list.
+func NetworkIntersectsWithNetworks(n *net.IPNet, networklist []*net.IPNet) bool {
+	for _, nw := range networklist {
+		if n.Contains(nw.IP) || nw.Contains(n.IP) {
+			return true
+		}
+	}
+	return false
+}
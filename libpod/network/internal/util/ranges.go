@@ -0,0 +1,70 @@
+package util
+
+import (
+	"bytes"
+	"net"
+
+	"github.com/containers/podman/v3/libpod/network/types"
+	"github.com/pkg/errors"
+)
+
+// compareIPs returns -1, 0 or 1 if ip1 is less than, equal to or greater
+// than ip2. Both IPs are normalized to 16 byte form before comparing so
+// mixed 4/16 byte representations of the same address compare equal.
+func compareIPs(ip1, ip2 net.IP) int {
+	return bytes.Compare(ip1.To16(), ip2.To16())
+}
+
+// rangeContains returns true if ip lies within the inclusive range
+// [start, end].
+func rangeContains(start, end, ip net.IP) bool {
+	return compareIPs(ip, start) >= 0 && compareIPs(ip, end) <= 0
+}
+
+// rangesOverlap returns true if the two inclusive ranges share any address.
+func rangesOverlap(start1, end1, start2, end2 net.IP) bool {
+	return compareIPs(start1, end2) <= 0 && compareIPs(start2, end1) <= 0
+}
+
+// validateExcludedRanges checks that every excluded range is contained in
+// the subnet and does not overlap the gateway or the configured lease
+// ranges.
+func validateExcludedRanges(s *types.Subnet) error {
+	for i := range s.ExcludedRanges {
+		er := &s.ExcludedRanges[i]
+		if er.StartIP == nil || er.EndIP == nil {
+			return errors.New("excluded range must have a start and end ip")
+		}
+		if !s.Subnet.Contains(er.StartIP) || !s.Subnet.Contains(er.EndIP) {
+			return errors.Errorf("excluded range %s-%s is not contained in subnet %s", er.StartIP, er.EndIP, &s.Subnet)
+		}
+		if compareIPs(er.StartIP, er.EndIP) > 0 {
+			return errors.Errorf("excluded range start ip %s is after end ip %s", er.StartIP, er.EndIP)
+		}
+		if s.Gateway != nil && rangeContains(er.StartIP, er.EndIP, s.Gateway) {
+			return errors.Errorf("excluded range %s-%s overlaps with gateway %s", er.StartIP, er.EndIP, s.Gateway)
+		}
+		for _, lr := range s.LeaseRanges {
+			if lr.StartIP == nil || lr.EndIP == nil {
+				continue
+			}
+			if rangesOverlap(er.StartIP, er.EndIP, lr.StartIP, lr.EndIP) {
+				return errors.Errorf("excluded range %s-%s overlaps with lease range %s-%s",
+					er.StartIP, er.EndIP, lr.StartIP, lr.EndIP)
+			}
+		}
+	}
+	return nil
+}
+
+// IPInExcludedRanges returns true if ip falls inside one of the subnet's
+// excluded ranges. Allocators must call this before handing an address out
+// to a container so reserved addresses are never assigned.
+func IPInExcludedRanges(s *types.Subnet, ip net.IP) bool {
+	for _, er := range s.ExcludedRanges {
+		if rangeContains(er.StartIP, er.EndIP, ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -47,19 +47,37 @@ func ValidateSubnet(s *types.Subnet, addGateway bool, usedNetworks []*net.IPNet)
 		s.Gateway = ip
 	}
 
-	if s.LeaseRange != nil {
-		if s.LeaseRange.StartIP != nil {
-			if !s.Subnet.Contains(s.LeaseRange.StartIP) {
-				return errors.Errorf("lease range start ip %s not in subnet %s", s.LeaseRange.StartIP, &s.Subnet)
+	for i := range s.LeaseRanges {
+		lr := &s.LeaseRanges[i]
+		if lr.StartIP != nil {
+			if !s.Subnet.Contains(lr.StartIP) {
+				return errors.Errorf("lease range start ip %s not in subnet %s", lr.StartIP, &s.Subnet)
 			}
-			util.NormalizeIP(&s.LeaseRange.StartIP)
+			util.NormalizeIP(&lr.StartIP)
 		}
-		if s.LeaseRange.EndIP != nil {
-			if !s.Subnet.Contains(s.LeaseRange.EndIP) {
-				return errors.Errorf("lease range end ip %s not in subnet %s", s.LeaseRange.EndIP, &s.Subnet)
+		if lr.EndIP != nil {
+			if !s.Subnet.Contains(lr.EndIP) {
+				return errors.Errorf("lease range end ip %s not in subnet %s", lr.EndIP, &s.Subnet)
 			}
-			util.NormalizeIP(&s.LeaseRange.EndIP)
+			util.NormalizeIP(&lr.EndIP)
 		}
+		if lr.StartIP != nil && lr.EndIP != nil && compareIPs(lr.StartIP, lr.EndIP) > 0 {
+			return errors.Errorf("lease range start ip %s is after end ip %s", lr.StartIP, lr.EndIP)
+		}
+		if s.Gateway != nil && lr.StartIP != nil && lr.EndIP != nil && rangeContains(lr.StartIP, lr.EndIP, s.Gateway) {
+			return errors.Errorf("gateway %s must not be inside lease range %s-%s", s.Gateway, lr.StartIP, lr.EndIP)
+		}
+		for j := range s.LeaseRanges[:i] {
+			other := &s.LeaseRanges[j]
+			if lr.StartIP != nil && lr.EndIP != nil && other.StartIP != nil && other.EndIP != nil &&
+				rangesOverlap(lr.StartIP, lr.EndIP, other.StartIP, other.EndIP) {
+				return errors.Errorf("lease range %s-%s overlaps with lease range %s-%s", lr.StartIP, lr.EndIP, other.StartIP, other.EndIP)
+			}
+		}
+	}
+
+	if err := validateExcludedRanges(s); err != nil {
+		return err
 	}
 	return nil
 }
@@ -90,6 +108,13 @@ func ValidateSetupOptions(n NetUtil, namespacePath string, options types.SetupOp
 	if len(options.Networks) == 0 {
 		return errors.New("must specify at least one network")
 	}
+	// seenMACs catches a container requesting the same static mac on two
+	// of its own network attachments. Catching a mac already leased to a
+	// *different* container additionally requires n to implement
+	// NetUtilMACChecker, since only the backend tracks other containers'
+	// leases.
+	seenMACs := map[string]string{}
+	macChecker, _ := n.(NetUtilMACChecker)
 	for name, netOpts := range options.Networks {
 		network, err := n.Network(name)
 		if err != nil {
@@ -99,15 +124,68 @@ func ValidateSetupOptions(n NetUtil, namespacePath string, options types.SetupOp
 		if err != nil {
 			return err
 		}
+		for _, mac := range staticMACs(netOpts) {
+			key := mac.String()
+			if other, ok := seenMACs[key]; ok {
+				return errors.Errorf("static mac %s requested on both network %s and %s", key, other, name)
+			}
+			seenMACs[key] = name
+			if macChecker != nil {
+				if containerID, leased := macChecker.MACLeased(name, mac); leased && containerID != options.ContainerID {
+					return errors.Errorf("static mac %s is already leased to container %s on network %s", key, containerID, name)
+				}
+			}
+		}
 	}
 	return nil
 }
 
+// staticMACs returns all static mac addresses requested in netOpts.
+func staticMACs(netOpts types.PerNetworkOptions) []net.HardwareAddr {
+	macs := make([]net.HardwareAddr, 0, len(netOpts.StaticMACs)+1)
+	macs = append(macs, netOpts.StaticMACs...)
+	if netOpts.StaticMAC != nil {
+		macs = append(macs, netOpts.StaticMAC)
+	}
+	return macs
+}
+
 // validatePerNetworkOpts checks that all given static ips are in a subnet on this network
 func validatePerNetworkOpts(network *types.Network, netOpts types.PerNetworkOptions) error {
 	if netOpts.InterfaceName == "" {
 		return errors.Errorf("interface name on network %s is empty", network.Name)
 	}
+
+	if netOpts.StaticMAC != nil {
+		if err := ValidateStaticMAC(netOpts.StaticMAC); err != nil {
+			return errors.Wrapf(err, "invalid static mac on network %s", network.Name)
+		}
+	}
+	if netOpts.StaticMACs != nil && len(netOpts.StaticMACs) != len(netOpts.StaticIPs) {
+		return errors.Errorf("static macs must contain exactly one entry per static ip on network %s", network.Name)
+	}
+	for _, mac := range netOpts.StaticMACs {
+		if err := ValidateStaticMAC(mac); err != nil {
+			return errors.Wrapf(err, "invalid static mac on network %s", network.Name)
+		}
+	}
+
+	// An external IPAM driver may own pools outside of any configured
+	// Subnet, so hand the containment check to it instead of walking
+	// network.Subnets below.
+	if netOpts.IPAMDriver != "" {
+		driver, ok := getIPAMDriver(netOpts.IPAMDriver)
+		if !ok {
+			return errors.Errorf("unknown ipam driver %q requested on network %s", netOpts.IPAMDriver, network.Name)
+		}
+		for _, ip := range netOpts.StaticIPs {
+			if !driver.Contains(network, ip) {
+				return errors.Errorf("requested static ip %s not managed by ipam driver %q on network %s", ip.String(), netOpts.IPAMDriver, network.Name)
+			}
+		}
+		return nil
+	}
+
 outer:
 	for _, ip := range netOpts.StaticIPs {
 		for _, s := range network.Subnets {
@@ -0,0 +1,93 @@
+package util
+
+import (
+	"net"
+	"testing"
+
+	"github.com/containers/podman/v3/libpod/network/types"
+)
+
+func mustParseCIDR(t *testing.T, cidr string) types.IPNet {
+	t.Helper()
+	_, n, err := net.ParseCIDR(cidr)
+	if err != nil {
+		t.Fatalf("invalid test cidr %s: %v", cidr, err)
+	}
+	return types.IPNet{IPNet: *n}
+}
+
+func TestValidateSubnetExcludedRanges(t *testing.T) {
+	tests := []struct {
+		name    string
+		subnet  types.Subnet
+		wantErr bool
+	}{
+		{
+			name: "valid excluded range inside subnet",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				ExcludedRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.240"), EndIP: net.ParseIP("192.168.1.250")},
+				},
+			},
+		},
+		{
+			name: "excluded range outside subnet",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				ExcludedRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.2.1"), EndIP: net.ParseIP("192.168.2.10")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "excluded range overlaps gateway",
+			subnet: types.Subnet{
+				Subnet:  mustParseCIDR(t, "192.168.1.0/24"),
+				Gateway: net.ParseIP("192.168.1.5"),
+				ExcludedRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.1"), EndIP: net.ParseIP("192.168.1.10")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "excluded range overlaps lease range",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.10"), EndIP: net.ParseIP("192.168.1.50")},
+				},
+				ExcludedRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.40"), EndIP: net.ParseIP("192.168.1.60")},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "excluded range does not overlap disjoint lease range",
+			subnet: types.Subnet{
+				Subnet: mustParseCIDR(t, "192.168.1.0/24"),
+				LeaseRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.10"), EndIP: net.ParseIP("192.168.1.50")},
+				},
+				ExcludedRanges: []types.LeaseRange{
+					{StartIP: net.ParseIP("192.168.1.200"), EndIP: net.ParseIP("192.168.1.210")},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateSubnet(&tt.subnet, false, nil)
+			if tt.wantErr && err == nil {
+				t.Fatalf("expected an error, got none")
+			}
+			if !tt.wantErr && err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+	}
+}
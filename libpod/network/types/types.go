@@ -0,0 +1,118 @@
+package types
+
+import (
+	"encoding/json"
+	"net"
+)
+
+// Network describes the Network attributes.
+type Network struct {
+	// Name of the Network.
+	Name string `json:"name"`
+	// Driver for this Network, e.g. bridge, macvlan.
+	Driver string `json:"driver"`
+	// Subnets to use for this network.
+	Subnets []Subnet `json:"subnets,omitempty"`
+	// Internal whether the Network should not have external routes
+	// to public or other Networks.
+	Internal bool `json:"internal"`
+	// IPv6Enabled if set to true an ipv6 subnet should be created for this net.
+	IPv6Enabled bool `json:"ipv6_enabled"`
+}
+
+// Subnet for a network.
+type Subnet struct {
+	// Subnet for this Network in CIDR form.
+	Subnet IPNet `json:"subnet"`
+	// Gateway IP for this Network.
+	Gateway net.IP `json:"gateway,omitempty"`
+	// LeaseRanges contains the ranges where IPs are leased. Supplying more
+	// than one range allows administrators to carve discontiguous pools
+	// out of a single subnet, e.g. to work around static assignments.
+	// Optional.
+	LeaseRanges []LeaseRange `json:"lease_ranges,omitempty"`
+	// ExcludedRanges are ranges inside the subnet which must never be
+	// handed out by the allocator, e.g. reserved infrastructure IPs,
+	// anycast gateways or other addresses that collide with IPs managed
+	// outside of this subnet. Optional.
+	ExcludedRanges []LeaseRange `json:"excluded_ranges,omitempty"`
+}
+
+// LeaseRange describes the range where IP are leased.
+type LeaseRange struct {
+	// StartIP first IP in the subnet which should be used to assign ips.
+	StartIP net.IP `json:"start_ip,omitempty"`
+	// EndIP last IP in the subnet which should be used to assign ips.
+	EndIP net.IP `json:"end_ip,omitempty"`
+}
+
+// IPNet is used as a wrapper for net.IPNet so it can be (un)marshaled to/from
+// its string representation, which is what users and config files expect.
+type IPNet struct {
+	net.IPNet
+}
+
+func (n *IPNet) String() string {
+	return n.IPNet.String()
+}
+
+// MarshalJSON marshals the IPNet as its CIDR string representation.
+func (n IPNet) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.String())
+}
+
+// UnmarshalJSON unmarshals the IPNet from its CIDR string representation.
+func (n *IPNet) UnmarshalJSON(data []byte) error {
+	var str string
+	if err := json.Unmarshal(data, &str); err != nil {
+		return err
+	}
+	_, network, err := net.ParseCIDR(str)
+	if err != nil {
+		return err
+	}
+	n.IPNet = *network
+	return nil
+}
+
+// PerNetworkOptions are options which should be set on a per network basis.
+type PerNetworkOptions struct {
+	// StaticIPs for this container. Optional.
+	StaticIPs []net.IP `json:"static_ips,omitempty"`
+	// Aliases contains a list of names which the dns server should resolve
+	// to this container. Should only be set when DNSEnabled is true on the Network.
+	// If aliases are set but there is no dns support for this network the
+	// network interface implementation should ignore this and NOT error.
+	// Optional.
+	Aliases []string `json:"aliases,omitempty"`
+	// InterfaceName for this container. Required.
+	InterfaceName string `json:"interface_name"`
+	// IPAMDriver is the name of the IPAM driver responsible for address
+	// allocation on this network attachment, e.g. "host-local", "dhcp",
+	// or the name of an out-of-process plugin. Empty means the built-in
+	// subnet allocator is used. Optional.
+	IPAMDriver string `json:"ipam_driver,omitempty"`
+	// StaticMAC for this container. Optional.
+	StaticMAC net.HardwareAddr `json:"static_mac,omitempty"`
+	// StaticMACs for this container, one per entry in StaticIPs, for
+	// containers that request more than one address on this network.
+	// Optional.
+	StaticMACs []net.HardwareAddr `json:"static_macs,omitempty"`
+}
+
+// NetworkOptions for a given container.
+type NetworkOptions struct {
+	// ContainerID is the container id, used for iptables comments and ipam allocation.
+	ContainerID string `json:"container_id"`
+	// ContainerName is the container name, used as dns name for the
+	// dns server to resolve to this container's ip.
+	ContainerName string `json:"container_name"`
+	// Networks contains the name of the networks and per network options.
+	Networks map[string]PerNetworkOptions `json:"networks"`
+}
+
+// SetupOptions are passed to Setup() to configure the network
+// namespace of a container.
+type SetupOptions struct {
+	NetworkOptions
+}
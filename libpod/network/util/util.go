@@ -0,0 +1,52 @@
+package util
+
+import (
+	"net"
+
+	"github.com/pkg/errors"
+)
+
+// NormalizeIP will transform the given ip to the 4 byte len ipv4 if possible.
+func NormalizeIP(ip *net.IP) {
+	ipv4 := ip.To4()
+	if ipv4 != nil {
+		*ip = ipv4
+	}
+}
+
+// IsIPv6 returns true if an IP is IPv6.
+func IsIPv6(ip net.IP) bool {
+	return ip != nil && ip.To4() == nil
+}
+
+// IsIPv4 returns true if an IP is IPv4.
+func IsIPv4(ip net.IP) bool {
+	return ip.To4() != nil
+}
+
+// FirstIPInSubnet returns the first IP in a given subnet.
+func FirstIPInSubnet(subnet *net.IPNet) (net.IP, error) {
+	ip := subnet.IP.Mask(subnet.Mask)
+	NormalizeIP(&ip)
+	ip[len(ip)-1]++
+	if !subnet.Contains(ip) {
+		return nil, errors.Errorf("no ip available in subnet %s", subnet.String())
+	}
+	return ip, nil
+}
+
+// LastIPInSubnet returns the last IP in a given subnet.
+func LastIPInSubnet(subnet *net.IPNet) (net.IP, error) {
+	// subnet.IP and subnet.Mask are always the same length (4 bytes for
+	// an IPv4 net.IPNet, 16 for IPv6), so size off subnet.IP directly
+	// instead of the always-non-nil result of To4()/To16().
+	ip := make(net.IP, len(subnet.IP))
+	for i := range ip {
+		ip[i] = subnet.IP[i] | ^subnet.Mask[i]
+	}
+	NormalizeIP(&ip)
+	if !subnet.Contains(ip) {
+		return nil, errors.Errorf("no ip available in subnet %s", subnet.String())
+	}
+	return ip, nil
+}
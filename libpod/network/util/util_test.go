@@ -0,0 +1,41 @@
+package util
+
+import (
+	"net"
+	"testing"
+)
+
+func TestFirstAndLastIPInSubnet(t *testing.T) {
+	tests := []struct {
+		name      string
+		cidr      string
+		wantFirst string
+		wantLast  string
+	}{
+		{"ipv4 /24", "192.168.1.0/24", "192.168.1.1", "192.168.1.255"},
+		{"ipv4 /30", "192.168.1.0/30", "192.168.1.1", "192.168.1.3"},
+		{"ipv6 /64", "fd00:1234::/64", "fd00:1234::1", "fd00:1234::ffff:ffff:ffff:ffff"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, subnet, err := net.ParseCIDR(tt.cidr)
+			if err != nil {
+				t.Fatalf("invalid test cidr %s: %v", tt.cidr, err)
+			}
+			first, err := FirstIPInSubnet(subnet)
+			if err != nil {
+				t.Fatalf("FirstIPInSubnet(%s): unexpected error: %v", tt.cidr, err)
+			}
+			if first.String() != tt.wantFirst {
+				t.Errorf("FirstIPInSubnet(%s) = %s, want %s", tt.cidr, first, tt.wantFirst)
+			}
+			last, err := LastIPInSubnet(subnet)
+			if err != nil {
+				t.Fatalf("LastIPInSubnet(%s): unexpected error: %v", tt.cidr, err)
+			}
+			if last.String() != tt.wantLast {
+				t.Errorf("LastIPInSubnet(%s) = %s, want %s", tt.cidr, last, tt.wantLast)
+			}
+		})
+	}
+}